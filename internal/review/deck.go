@@ -0,0 +1,241 @@
+package review
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// deckVersion identifies the canonical deck format produced by MarshalDeck.
+const deckVersion = 1
+
+// deckEntry is the on-disk representation of an Entry within a deck.
+//
+// Recall is a pointer so a missing field can be told apart from an explicit
+// zero value: Recall(0) is Failed, not NotReviewed, so falling back to Go's
+// zero value for an omitted field would silently mis-bucket brand-new cards
+// as lapsed ones.
+type deckEntry struct {
+	ID          int       `json:"id" yaml:"id"`
+	Title       string    `json:"title" yaml:"title"`
+	Description string    `json:"description,omitempty" yaml:"description,omitempty"`
+	Category    string    `json:"category" yaml:"category"`
+	Recall      *Recall   `json:"recall,omitempty" yaml:"recall,omitempty"`
+	Interval    int       `json:"interval" yaml:"interval"`
+	ReviewDate  time.Time `json:"reviewDate" yaml:"reviewDate"`
+	LastReview  time.Time `json:"lastReview,omitempty" yaml:"lastReview,omitempty"`
+	CreatedAt   time.Time `json:"createdAt" yaml:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt,omitempty" yaml:"updatedAt,omitempty"`
+	EaseFactor  float64   `json:"easeFactor" yaml:"easeFactor"`
+	Repetitions int       `json:"repetitions" yaml:"repetitions"`
+	Lapses      int       `json:"lapses" yaml:"lapses"`
+}
+
+// deck is the canonical top-level format for an exported collection of
+// entries.
+type deck struct {
+	Version int         `json:"version" yaml:"version"`
+	Entries []deckEntry `json:"entries" yaml:"entries"`
+}
+
+func fromEntry(e *Entry) deckEntry {
+	recall := e.Recall
+	return deckEntry{
+		ID:          e.ID,
+		Title:       e.Title,
+		Description: e.Description,
+		Category:    e.Category,
+		Recall:      &recall,
+		Interval:    e.Interval,
+		ReviewDate:  e.ReviewDate,
+		LastReview:  e.LastReview,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+		EaseFactor:  e.EaseFactor,
+		Repetitions: e.Repetitions,
+		Lapses:      e.Lapses,
+	}
+}
+
+// toEntry validates de using the same rules as NewEntry, wrapping any
+// failure with the offending entry's index so callers can report exactly
+// which entry in the deck was malformed.
+func (de deckEntry) toEntry(index int) (*Entry, error) {
+	title := strings.TrimSpace(de.Title)
+	description := strings.TrimSpace(de.Description)
+	category := strings.TrimSpace(de.Category)
+
+	if err := validateTitle(title); err != nil {
+		return nil, fmt.Errorf("deck: entry %d: %w", index, err)
+	}
+	if err := validateDescription(description); err != nil {
+		return nil, fmt.Errorf("deck: entry %d: %w", index, err)
+	}
+	if err := validateCategory(category); err != nil {
+		return nil, fmt.Errorf("deck: entry %d: %w", index, err)
+	}
+
+	recall := NotReviewed
+	if de.Recall != nil {
+		recall = *de.Recall
+	}
+	if recall != NotReviewed {
+		if err := validateRecall(recall); err != nil {
+			return nil, fmt.Errorf("deck: entry %d: %w", index, err)
+		}
+	}
+
+	ease := de.EaseFactor
+	if ease == 0 {
+		ease = DefaultEaseFactor
+	}
+
+	return &Entry{
+		ID:          de.ID,
+		Title:       title,
+		Description: description,
+		Category:    category,
+		Recall:      recall,
+		Interval:    de.Interval,
+		ReviewDate:  de.ReviewDate,
+		LastReview:  de.LastReview,
+		CreatedAt:   de.CreatedAt,
+		UpdatedAt:   de.UpdatedAt,
+		EaseFactor:  ease,
+		Repetitions: de.Repetitions,
+		Lapses:      de.Lapses,
+	}, nil
+}
+
+// MarshalDeck encodes entries as the canonical JSON deck format: a top-level
+// version plus an entries array.
+func MarshalDeck(entries []*Entry) ([]byte, error) {
+	d := deck{Version: deckVersion, Entries: make([]deckEntry, len(entries))}
+	for i, e := range entries {
+		d.Entries[i] = fromEntry(e)
+	}
+
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("deck: marshal: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalDeck decodes a deck from either JSON or YAML, running the same
+// validation NewEntry applies to every entry. YAML input is converted to
+// JSON internally so only one code path validates.
+func UnmarshalDeck(data []byte) ([]*Entry, error) {
+	data, err := normalizeToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var d deck
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("deck: unmarshal: %w", err)
+	}
+
+	entries := make([]*Entry, len(d.Entries))
+	for i, de := range d.Entries {
+		entry, err := de.toEntry(i)
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = entry
+	}
+	return entries, nil
+}
+
+// normalizeToJSON converts YAML input to the canonical JSON representation.
+// JSON input passes through unchanged.
+func normalizeToJSON(data []byte) ([]byte, error) {
+	if json.Valid(data) {
+		return data, nil
+	}
+
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("deck: invalid JSON/YAML: %w", err)
+	}
+
+	converted, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("deck: %w", err)
+	}
+	return converted, nil
+}
+
+// Decoder reads entries one at a time from a canonical JSON deck, so large
+// decks can be imported without loading the whole file into memory.
+type Decoder struct {
+	dec     *json.Decoder
+	index   int
+	started bool
+	done    bool
+}
+
+// NewDecoder returns a Decoder that reads a deck from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{dec: json.NewDecoder(r)}
+}
+
+// Decode returns the next entry in the deck, or io.EOF once all entries
+// have been read.
+func (d *Decoder) Decode() (*Entry, error) {
+	if d.done {
+		return nil, io.EOF
+	}
+
+	if !d.started {
+		if err := d.init(); err != nil {
+			return nil, err
+		}
+	}
+
+	if !d.dec.More() {
+		d.done = true
+		return nil, io.EOF
+	}
+
+	var de deckEntry
+	if err := d.dec.Decode(&de); err != nil {
+		return nil, fmt.Errorf("deck: decode entry %d: %w", d.index, err)
+	}
+
+	entry, err := de.toEntry(d.index)
+	d.index++
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// init advances the underlying decoder past the deck's leading tokens up to
+// the opening bracket of the entries array.
+func (d *Decoder) init() error {
+	for {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return fmt.Errorf("deck: %w", err)
+		}
+		if key, ok := tok.(string); ok && key == "entries" {
+			break
+		}
+	}
+
+	tok, err := d.dec.Token()
+	if err != nil {
+		return fmt.Errorf("deck: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("deck: expected entries array")
+	}
+
+	d.started = true
+	return nil
+}
@@ -0,0 +1,156 @@
+package review_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/eeay0/cortex/internal/review"
+)
+
+func TestReview_MarshalUnmarshalDeckRoundTrip(t *testing.T) {
+	entry, err := review.NewEntry("test entry", review.WithCategory("go"))
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+
+	data, err := review.MarshalDeck([]*review.Entry{entry})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := review.UnmarshalDeck(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(got))
+	}
+	if got[0].Title != entry.Title || got[0].Category != entry.Category {
+		t.Errorf("expected %+v, got %+v", entry, got[0])
+	}
+}
+
+func TestReview_UnmarshalDeckMissingRecallDefaultsToNotReviewed(t *testing.T) {
+	yaml := `
+entries:
+  - title: hand written
+    category: go
+`
+	entries, err := review.UnmarshalDeck([]byte(yaml))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entries[0].Recall != review.NotReviewed {
+		t.Errorf("expected a missing recall field to default to NotReviewed, got %v", entries[0].Recall)
+	}
+}
+
+func TestReview_UnmarshalDeckYAML(t *testing.T) {
+	yaml := `
+version: 1
+entries:
+  - title: from yaml
+    category: go
+    recall: -1
+    interval: 1
+    easeFactor: 2.5
+`
+	entries, err := review.UnmarshalDeck([]byte(yaml))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Title != "from yaml" {
+		t.Errorf("expected 1 entry titled %q, got %+v", "from yaml", entries)
+	}
+}
+
+func TestReview_UnmarshalDeckTrimsBeforeValidating(t *testing.T) {
+	json := `{"version":1,"entries":[
+		{"title":"   padded   ","category":"  go  ","recall":-1}
+	]}`
+
+	entries, err := review.UnmarshalDeck([]byte(json))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entries[0].Title != "padded" || entries[0].Category != "go" {
+		t.Errorf("expected trimmed title/category, got %+v", entries[0])
+	}
+}
+
+func TestReview_UnmarshalDeckRejectsWhitespaceOnlyTitle(t *testing.T) {
+	json := `{"version":1,"entries":[
+		{"title":"   ","category":"go","recall":-1}
+	]}`
+
+	_, err := review.UnmarshalDeck([]byte(json))
+	if !errors.Is(err, review.ErrTitleEmpty) {
+		t.Errorf("expected wrapped ErrTitleEmpty for a whitespace-only title, got %v", err)
+	}
+}
+
+func TestReview_UnmarshalDeckValidationNamesIndex(t *testing.T) {
+	json := `{"version":1,"entries":[
+		{"title":"valid","category":"go","recall":-1},
+		{"title":"","category":"go","recall":-1}
+	]}`
+
+	_, err := review.UnmarshalDeck([]byte(json))
+	if err == nil {
+		t.Fatal("expected error for empty title, got nil")
+	}
+	if !errors.Is(err, review.ErrTitleEmpty) {
+		t.Errorf("expected wrapped ErrTitleEmpty, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "entry 1") {
+		t.Errorf("expected error to name entry index 1, got %q", err.Error())
+	}
+}
+
+func TestReview_DecoderStreamsEntries(t *testing.T) {
+	entries := []*review.Entry{}
+	for _, title := range []string{"a", "b", "c"} {
+		e, err := review.NewEntry(title)
+		if err != nil {
+			t.Fatalf("failed to crate entry: %v", err)
+		}
+		entries = append(entries, e)
+	}
+
+	data, err := review.MarshalDeck(entries)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dec := review.NewDecoder(bytes.NewReader(data))
+	var got []string
+	for {
+		e, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, e.Title)
+	}
+
+	if len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Errorf("expected [a b c], got %v", got)
+	}
+}
+
+func TestReview_DecoderEmptyDeck(t *testing.T) {
+	data, err := review.MarshalDeck(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dec := review.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("expected io.EOF for an empty deck, got %v", err)
+	}
+}
@@ -30,6 +30,14 @@ const day = time.Hour * 24
 
 const intervalLimit = 90
 
+// DefaultEaseFactor is the SM-2 ease factor assigned to new entries, and the
+// value entries created before EaseFactor existed are treated as having.
+const DefaultEaseFactor = 2.5
+
+// minEaseFactor is the floor SM-2 clamps EaseFactor to, preventing entries
+// that are repeatedly failed from spiraling into ever-shrinking intervals.
+const minEaseFactor = 1.3
+
 // Recall represents user's recall performance.
 // It's being used to calculate interval.
 type Recall float32
@@ -60,6 +68,23 @@ func (p Recall) String() string {
 	}
 }
 
+// quality maps a Recall rating onto the 0-5 quality scale SM-2 expects.
+// It returns -1 for anything that doesn't correspond to a graded recall.
+func (p Recall) quality() int {
+	switch p {
+	case Failed:
+		return 0
+	case Hard:
+		return 3
+	case Good:
+		return 4
+	case Easy:
+		return 5
+	default:
+		return -1
+	}
+}
+
 // Entry represents a topic to be reviewed.
 type Entry struct {
 	ID          int
@@ -72,6 +97,14 @@ type Entry struct {
 	LastReview  time.Time
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+
+	// EaseFactor, Repetitions and Lapses are SM-2 scheduling state. Entries
+	// decoded from before these fields existed have EaseFactor == 0, which
+	// SM2Scheduler treats as DefaultEaseFactor, so no explicit migration
+	// step is required.
+	EaseFactor  float64
+	Repetitions int
+	Lapses      int
 }
 
 // INFO: Validation
@@ -161,6 +194,9 @@ func NewEntry(title string, options ...Option) (*Entry, error) {
 		LastReview:  time.Time{},
 		CreatedAt:   time.Now().UTC(),
 		UpdatedAt:   time.Time{},
+		EaseFactor:  DefaultEaseFactor,
+		Repetitions: 0,
+		Lapses:      0,
 	}
 
 	for _, option := range options {
@@ -175,25 +211,6 @@ func NewEntry(title string, options ...Option) (*Entry, error) {
 	return entry, nil
 }
 
-// INFO: Helper
-
-func (e *Entry) calculateInterval() (int, error) {
-	switch e.Recall {
-	case NotReviewed:
-		return 1, nil
-	case Failed:
-		return 1, nil
-	case Hard:
-		return int(float32(e.Interval) * float32(Hard)), nil
-	case Good:
-		return int(float32(e.Interval) * float32(Good)), nil
-	case Easy:
-		return int(float32(e.Interval) * float32(Easy)), nil
-	default:
-		return -1, ErrInvalidRecall
-	}
-}
-
 // INFO: Update
 
 // UpdateTitle updates the title of the entry after validating the new title.
@@ -244,20 +261,18 @@ func (e *Entry) UpdateCategory(category string) error {
 	return nil
 }
 
-// UpdateInterval updates the interval and next review date after calculating the interval day
-// based on the users last recall.
+// UpdateInterval recalculates the interval and next review date from the
+// entry's last recall, using the package's configured Scheduler. It is kept
+// as a thin wrapper around the Scheduler so existing callers don't need to
+// change when the scheduling algorithm does.
 func (e *Entry) UpdateInterval() error {
-	newInterval, err := e.calculateInterval()
+	newInterval, due, err := scheduler.Next(e, e.Recall, time.Now())
 	if err != nil {
 		return err
 	}
 
-	if newInterval > intervalLimit {
-		newInterval = intervalLimit
-	}
-
 	e.Interval = newInterval
-	e.ReviewDate = time.Now().Add(day * time.Duration(newInterval)).UTC()
+	e.ReviewDate = due.UTC()
 	e.UpdatedAt = time.Now().UTC()
 	return nil
 }
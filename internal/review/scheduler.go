@@ -0,0 +1,93 @@
+package review
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Scheduler computes the next interval and due date for an entry given a
+// recall rating. It's the extension point that lets alternative spacing
+// algorithms (Leitner, FSRS, ...) be swapped in via SetScheduler.
+type Scheduler interface {
+	// Next returns the interval in days and the resulting due date for e
+	// after being reviewed with recall r at now. Implementations may mutate
+	// e's scheduling state (EaseFactor, Repetitions, Lapses) but must not
+	// set e.Interval or e.ReviewDate directly; the caller does that.
+	Next(e *Entry, r Recall, now time.Time) (interval int, due time.Time, err error)
+}
+
+// scheduler is the package-wide Scheduler used by Entry.UpdateInterval.
+var scheduler Scheduler = &SM2Scheduler{}
+
+// SetScheduler overrides the Scheduler used by Entry.UpdateInterval. It's
+// not safe to call concurrently with scheduling operations.
+func SetScheduler(s Scheduler) {
+	scheduler = s
+}
+
+// SM2Scheduler implements the SuperMemo-2 spaced repetition algorithm.
+type SM2Scheduler struct {
+	// Jitter spreads due dates by up to this duration to avoid review
+	// pile-ups on the same day. Zero (the default) disables jitter.
+	Jitter time.Duration
+}
+
+// Next implements Scheduler using the classic SM-2 recurrence: a quality
+// below 3 resets repetitions and drops the interval back to a day, while a
+// passing quality grows the interval from the fixed 1/6-day first steps to
+// interval*EaseFactor, with EaseFactor itself adjusted by how well the
+// recall went. An entry that has never been reviewed (r == NotReviewed) is
+// simply scheduled a day out, matching the original scheduling behavior for
+// brand-new entries.
+func (s *SM2Scheduler) Next(e *Entry, r Recall, now time.Time) (int, time.Time, error) {
+	if r == NotReviewed {
+		return 1, now.Add(day), nil
+	}
+
+	q := r.quality()
+	if q < 0 {
+		return 0, time.Time{}, ErrInvalidRecall
+	}
+
+	ease := e.EaseFactor
+	if ease == 0 {
+		ease = DefaultEaseFactor
+	}
+
+	var interval int
+	if q < 3 {
+		e.Lapses++
+		e.Repetitions = 0
+		interval = 1
+	} else {
+		switch e.Repetitions {
+		case 0:
+			interval = 1
+		case 1:
+			interval = 6
+		default:
+			// Use the ease factor as it stood going into this review; it's
+			// only updated for the review after this one.
+			interval = int(math.Round(float64(e.Interval) * ease))
+		}
+		e.Repetitions++
+	}
+
+	if interval > intervalLimit {
+		interval = intervalLimit
+	}
+
+	ease += 0.1 - float64(5-q)*(0.08+float64(5-q)*0.02)
+	if ease < minEaseFactor {
+		ease = minEaseFactor
+	}
+	e.EaseFactor = ease
+
+	due := now.Add(day * time.Duration(interval))
+	if s.Jitter > 0 {
+		due = due.Add(time.Duration(rand.Int63n(int64(s.Jitter))))
+	}
+
+	return interval, due, nil
+}
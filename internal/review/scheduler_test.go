@@ -0,0 +1,146 @@
+package review_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eeay0/cortex/internal/review"
+)
+
+func TestReview_SM2Scheduler_ThirdIntervalUsesEaseFactor(t *testing.T) {
+	entry, err := review.NewEntry("test entry")
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+
+	// Two passing reviews walk Repetitions through the fixed 1/6-day steps.
+	entry.UpdateRecall(review.Good)
+	entry.UpdateInterval()
+	entry.UpdateRecall(review.Good)
+	entry.UpdateInterval()
+
+	if entry.Interval != 6 {
+		t.Fatalf("expected interval 6 after second review, got %d", entry.Interval)
+	}
+
+	entry.UpdateRecall(review.Good)
+	entry.UpdateInterval()
+
+	if entry.Interval <= 6 {
+		t.Errorf("expected third interval to grow past 6 via the EaseFactor multiplier, got %d", entry.Interval)
+	}
+}
+
+func TestReview_SM2Scheduler_FailureResetsRepetitionsAndCountsLapse(t *testing.T) {
+	entry, err := review.NewEntry("test entry")
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+
+	entry.UpdateRecall(review.Good)
+	entry.UpdateInterval()
+	entry.UpdateRecall(review.Good)
+	entry.UpdateInterval()
+
+	entry.UpdateRecall(review.Failed)
+	entry.UpdateInterval()
+
+	if entry.Interval != 1 {
+		t.Errorf("expected interval to reset to 1 after a failure, got %d", entry.Interval)
+	}
+	if entry.Repetitions != 0 {
+		t.Errorf("expected Repetitions to reset to 0, got %d", entry.Repetitions)
+	}
+	if entry.Lapses != 1 {
+		t.Errorf("expected Lapses to be 1, got %d", entry.Lapses)
+	}
+}
+
+func TestReview_SM2Scheduler_EaseFactorFloor(t *testing.T) {
+	entry, err := review.NewEntry("test entry")
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+
+	for i := 0; i < 20; i++ {
+		entry.UpdateRecall(review.Failed)
+		entry.UpdateInterval()
+	}
+
+	if entry.EaseFactor < 1.3 {
+		t.Errorf("expected EaseFactor to be floored at 1.3, got %f", entry.EaseFactor)
+	}
+}
+
+func TestReview_SM2Scheduler_IntervalCap(t *testing.T) {
+	entry, err := review.NewEntry("test entry")
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+
+	for i := 0; i < 20; i++ {
+		entry.UpdateRecall(review.Easy)
+		entry.UpdateInterval()
+	}
+
+	if entry.Interval > 90 {
+		t.Errorf("expected interval to be capped at 90, got %d", entry.Interval)
+	}
+}
+
+func TestReview_SM2Scheduler_InvalidRecall(t *testing.T) {
+	entry, err := review.NewEntry("test entry")
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+
+	sched := &review.SM2Scheduler{}
+	_, _, err = sched.Next(entry, 20, time.Now())
+	if err == nil {
+		t.Fatal("expected error for an out-of-range recall, got nil")
+	}
+}
+
+func TestReview_SM2Scheduler_NotReviewedSchedulesFirstReview(t *testing.T) {
+	entry, err := review.NewEntry("test entry")
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+
+	sched := &review.SM2Scheduler{}
+	interval, _, err := sched.Next(entry, review.NotReviewed, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error for NotReviewed recall: %v", err)
+	}
+	if interval != 1 {
+		t.Errorf("expected a 1-day interval for an unreviewed entry, got %d", interval)
+	}
+}
+
+func TestReview_SetScheduler(t *testing.T) {
+	t.Cleanup(func() { review.SetScheduler(&review.SM2Scheduler{}) })
+
+	review.SetScheduler(constantScheduler{interval: 42})
+
+	entry, err := review.NewEntry("test entry")
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+
+	entry.UpdateRecall(review.Good)
+	if err := entry.UpdateInterval(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if entry.Interval != 42 {
+		t.Errorf("expected custom scheduler to set interval 42, got %d", entry.Interval)
+	}
+}
+
+type constantScheduler struct {
+	interval int
+}
+
+func (c constantScheduler) Next(_ *review.Entry, _ review.Recall, now time.Time) (int, time.Time, error) {
+	return c.interval, now.Add(time.Duration(c.interval) * 24 * time.Hour), nil
+}
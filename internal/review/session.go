@@ -0,0 +1,230 @@
+package review
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// ErrNoPendingAnswer is returned by Answer when called before Next has
+// served an entry, or after the served entry has already been answered.
+var ErrNoPendingAnswer = errors.New("review: no entry awaiting an answer")
+
+// defaultLapseRequeueGap is used when WithLapseRequeueGap isn't given.
+const defaultLapseRequeueGap = 3
+
+// Order controls how a Session's queue is arranged before a review begins.
+type Order int
+
+// Orders supported by NewSession.
+const (
+	OrderRandom Order = iota
+	OrderOldestFirst
+	OrderShortestIntervalFirst
+)
+
+// SessionStats tracks per-session review counters.
+type SessionStats struct {
+	Again   int
+	Hard    int
+	Good    int
+	Easy    int
+	Elapsed time.Duration
+}
+
+// SessionOption configures a Session created by NewSession.
+type SessionOption func(*Session)
+
+// WithMaxNew caps the number of entries that have never been reviewed
+// (Recall == NotReviewed) admitted into the session.
+func WithMaxNew(n int) SessionOption {
+	return func(s *Session) {
+		s.maxNew = n
+	}
+}
+
+// WithMaxReviews caps the number of already-reviewed entries admitted into
+// the session.
+func WithMaxReviews(n int) SessionOption {
+	return func(s *Session) {
+		s.maxReviews = n
+	}
+}
+
+// WithOrder sets how the session's queue is arranged before the first call
+// to Next. The default is OrderRandom.
+func WithOrder(o Order) SessionOption {
+	return func(s *Session) {
+		s.order = o
+	}
+}
+
+// WithLapseRequeueGap sets how many other entries must be served before a
+// failed entry reappears in the same session. The default is 3; a gap of 0
+// requeues the entry immediately after the one just answered.
+func WithLapseRequeueGap(k int) SessionOption {
+	return func(s *Session) {
+		s.lapseRequeueGap = k
+	}
+}
+
+// WithCategoryFilter restricts the session to entries in one of the given
+// categories. With no categories given, all entries are eligible.
+func WithCategoryFilter(cats ...string) SessionOption {
+	return func(s *Session) {
+		s.categories = make(map[string]bool, len(cats))
+		for _, c := range cats {
+			s.categories[c] = true
+		}
+	}
+}
+
+// Session serves a bounded, ordered queue of due entries for a single
+// review pass, re-queuing failed entries so they come up again later in the
+// same session.
+type Session struct {
+	queue           []*Entry
+	current         *Entry
+	order           Order
+	maxNew          int
+	maxReviews      int
+	lapseRequeueGap int
+	categories      map[string]bool
+	stats           SessionStats
+	startedAt       time.Time
+}
+
+// NewSession builds a Session over entries, applying opts to filter,
+// bound, and order the resulting queue.
+func NewSession(entries []*Entry, opts ...SessionOption) *Session {
+	s := &Session{
+		maxNew:          -1,
+		maxReviews:      -1,
+		lapseRequeueGap: -1,
+		startedAt:       time.Now(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	eligible := entries
+	if len(s.categories) > 0 {
+		eligible = make([]*Entry, 0, len(entries))
+		for _, e := range entries {
+			if s.categories[e.Category] {
+				eligible = append(eligible, e)
+			}
+		}
+	}
+
+	var newCards, reviewCards []*Entry
+	for _, e := range eligible {
+		if e.Recall == NotReviewed {
+			newCards = append(newCards, e)
+		} else {
+			reviewCards = append(reviewCards, e)
+		}
+	}
+
+	if s.maxNew >= 0 && len(newCards) > s.maxNew {
+		newCards = newCards[:s.maxNew]
+	}
+	if s.maxReviews >= 0 && len(reviewCards) > s.maxReviews {
+		reviewCards = reviewCards[:s.maxReviews]
+	}
+
+	s.queue = append(newCards, reviewCards...)
+	s.applyOrder()
+
+	return s
+}
+
+func (s *Session) applyOrder() {
+	switch s.order {
+	case OrderOldestFirst:
+		sort.SliceStable(s.queue, func(i, j int) bool {
+			return s.queue[i].ReviewDate.Before(s.queue[j].ReviewDate)
+		})
+	case OrderShortestIntervalFirst:
+		sort.SliceStable(s.queue, func(i, j int) bool {
+			return s.queue[i].Interval < s.queue[j].Interval
+		})
+	default: // OrderRandom
+		rand.Shuffle(len(s.queue), func(i, j int) {
+			s.queue[i], s.queue[j] = s.queue[j], s.queue[i]
+		})
+	}
+}
+
+// Next pops and returns the next entry to review, or false if the session is
+// complete. The returned entry must be graded with Answer before the next
+// call to Next.
+func (s *Session) Next() (*Entry, bool) {
+	if len(s.queue) == 0 {
+		return nil, false
+	}
+
+	s.current = s.queue[0]
+	s.queue = s.queue[1:]
+	return s.current, true
+}
+
+// Answer grades the entry returned by the last call to Next, updating its
+// recall and interval, and re-queues it later in the session if r is
+// Failed.
+func (s *Session) Answer(r Recall) error {
+	if s.current == nil {
+		return ErrNoPendingAnswer
+	}
+
+	if err := s.current.UpdateRecall(r); err != nil {
+		return err
+	}
+	if err := s.current.UpdateInterval(); err != nil {
+		return err
+	}
+
+	switch r {
+	case Failed:
+		s.stats.Again++
+		s.requeue(s.current)
+	case Hard:
+		s.stats.Hard++
+	case Good:
+		s.stats.Good++
+	case Easy:
+		s.stats.Easy++
+	}
+
+	s.current = nil
+	return nil
+}
+
+func (s *Session) requeue(e *Entry) {
+	gap := s.lapseRequeueGap
+	if gap < 0 {
+		gap = defaultLapseRequeueGap
+	}
+
+	pos := gap
+	if pos > len(s.queue) {
+		pos = len(s.queue)
+	}
+
+	s.queue = append(s.queue[:pos:pos], append([]*Entry{e}, s.queue[pos:]...)...)
+}
+
+// Remaining returns the number of entries left to serve, not counting the
+// entry currently awaiting an answer.
+func (s *Session) Remaining() int {
+	return len(s.queue)
+}
+
+// Stats returns the session's counters so far, including elapsed wall-clock
+// time since the session was created.
+func (s *Session) Stats() SessionStats {
+	stats := s.stats
+	stats.Elapsed = time.Since(s.startedAt)
+	return stats
+}
@@ -0,0 +1,176 @@
+package review_test
+
+import (
+	"testing"
+
+	"github.com/eeay0/cortex/internal/review"
+)
+
+func newReviewedEntry(t *testing.T, title, category string) *review.Entry {
+	t.Helper()
+	entry, err := review.NewEntry(title, review.WithCategory(category))
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+	if err := entry.UpdateRecall(review.Good); err != nil {
+		t.Fatalf("failed to update recall: %v", err)
+	}
+	if err := entry.UpdateInterval(); err != nil {
+		t.Fatalf("failed to update interval: %v", err)
+	}
+	return entry
+}
+
+func TestReview_SessionServesAllEntries(t *testing.T) {
+	a := newReviewedEntry(t, "a", "go")
+	b := newReviewedEntry(t, "b", "go")
+	c := newReviewedEntry(t, "c", "go")
+
+	sess := review.NewSession([]*review.Entry{a, b, c})
+
+	seen := map[string]bool{}
+	for {
+		entry, ok := sess.Next()
+		if !ok {
+			break
+		}
+		seen[entry.Title] = true
+		if err := sess.Answer(review.Good); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(seen) != 3 {
+		t.Errorf("expected 3 distinct entries served, got %d", len(seen))
+	}
+	if sess.Remaining() != 0 {
+		t.Errorf("expected 0 remaining, got %d", sess.Remaining())
+	}
+}
+
+func TestReview_SessionAnswerWithoutNext(t *testing.T) {
+	sess := review.NewSession(nil)
+	if err := sess.Answer(review.Good); err != review.ErrNoPendingAnswer {
+		t.Errorf("expected ErrNoPendingAnswer, got %v", err)
+	}
+}
+
+func TestReview_SessionCategoryFilter(t *testing.T) {
+	a := newReviewedEntry(t, "a", "go")
+	b := newReviewedEntry(t, "b", "rust")
+
+	sess := review.NewSession([]*review.Entry{a, b}, review.WithCategoryFilter("go"))
+
+	if sess.Remaining() != 1 {
+		t.Fatalf("expected 1 remaining after filter, got %d", sess.Remaining())
+	}
+	entry, ok := sess.Next()
+	if !ok || entry.Category != "go" {
+		t.Errorf("expected only the %q entry, got %v", "go", entry)
+	}
+}
+
+func TestReview_SessionMaxNewAndMaxReviews(t *testing.T) {
+	newA, err := review.NewEntry("new a")
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+	newB, err := review.NewEntry("new b")
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+	reviewed := newReviewedEntry(t, "reviewed", "None")
+
+	sess := review.NewSession(
+		[]*review.Entry{newA, newB, reviewed},
+		review.WithMaxNew(1),
+		review.WithMaxReviews(0),
+	)
+
+	if sess.Remaining() != 1 {
+		t.Errorf("expected 1 remaining (1 new, 0 reviews), got %d", sess.Remaining())
+	}
+}
+
+func TestReview_SessionLapseRequeue(t *testing.T) {
+	a := newReviewedEntry(t, "a", "None")
+	b := newReviewedEntry(t, "b", "None")
+	c := newReviewedEntry(t, "c", "None")
+
+	sess := review.NewSession(
+		[]*review.Entry{a, b, c},
+		review.WithOrder(review.OrderOldestFirst),
+		review.WithLapseRequeueGap(1),
+	)
+
+	first, _ := sess.Next()
+	if first.Title != "a" {
+		t.Fatalf("expected entry %q first, got %q", "a", first.Title)
+	}
+	if err := sess.Answer(review.Failed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// "a" failed with a requeue gap of 1, so it should reappear after one
+	// other entry rather than at the very end.
+	second, _ := sess.Next()
+	if second.Title != "b" {
+		t.Fatalf("expected entry %q second, got %q", "b", second.Title)
+	}
+	if err := sess.Answer(review.Good); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	third, _ := sess.Next()
+	if third.Title != "a" {
+		t.Errorf("expected entry %q to be requeued after one card, got %q", "a", third.Title)
+	}
+}
+
+func TestReview_SessionLapseRequeueGapZero(t *testing.T) {
+	a := newReviewedEntry(t, "a", "None")
+	b := newReviewedEntry(t, "b", "None")
+
+	sess := review.NewSession(
+		[]*review.Entry{a, b},
+		review.WithOrder(review.OrderOldestFirst),
+		review.WithLapseRequeueGap(0),
+	)
+
+	first, _ := sess.Next()
+	if first.Title != "a" {
+		t.Fatalf("expected entry %q first, got %q", "a", first.Title)
+	}
+	if err := sess.Answer(review.Failed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A gap of 0 means "a" should come right back, ahead of "b".
+	second, _ := sess.Next()
+	if second.Title != "a" {
+		t.Errorf("expected entry %q to be requeued immediately, got %q", "a", second.Title)
+	}
+}
+
+func TestReview_SessionStats(t *testing.T) {
+	a := newReviewedEntry(t, "a", "None")
+	b := newReviewedEntry(t, "b", "None")
+
+	sess := review.NewSession([]*review.Entry{a, b})
+
+	entry, _ := sess.Next()
+	_ = entry
+	if err := sess.Answer(review.Easy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	entry, _ = sess.Next()
+	_ = entry
+	if err := sess.Answer(review.Hard); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stats := sess.Stats()
+	if stats.Easy != 1 || stats.Hard != 1 {
+		t.Errorf("expected 1 Easy and 1 Hard, got %+v", stats)
+	}
+}
@@ -0,0 +1,314 @@
+// Package store persists review.Entry values in an embedded key/value
+// database and indexes them by due date so "what's due today" queries don't
+// require a full scan.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eeay0/cortex/internal/review"
+	"github.com/tidwall/buntdb"
+)
+
+// schemaVersion is stored with every record so a future format change can
+// migrate old records forward on read.
+const schemaVersion = 1
+
+// ErrNotFound is returned when an entry with the given ID doesn't exist.
+var ErrNotFound = errors.New("store: entry not found")
+
+const (
+	dueIndex  = "review_date"
+	nextIDKey = "meta:next_id"
+)
+
+// record is the on-disk representation of an Entry.
+type record struct {
+	Version int           `json:"version"`
+	Entry   *review.Entry `json:"entry"`
+}
+
+func entryKey(id int) string {
+	return fmt.Sprintf("entry:%d", id)
+}
+
+// Store persists entries in an embedded buntdb database, keeping a
+// secondary index on ReviewDate for efficient due-date queries.
+type Store struct {
+	db        *buntdb.DB
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Open opens (creating if necessary) a Store backed by the database file at
+// path. Use ":memory:" for a non-persistent store.
+func Open(path string) (*Store, error) {
+	db, err := buntdb.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("store: open %s: %w", path, err)
+	}
+
+	err = db.CreateIndex(dueIndex, "entry:*", lessReviewDate)
+	if err != nil && err != buntdb.ErrIndexExists {
+		db.Close()
+		return nil, fmt.Errorf("store: create index: %w", err)
+	}
+
+	return &Store{db: db, done: make(chan struct{})}, nil
+}
+
+// Close closes the underlying database and stops any goroutines started by
+// DueChan.
+func (s *Store) Close() error {
+	s.closeOnce.Do(func() { close(s.done) })
+	return s.db.Close()
+}
+
+// Tx wraps a buntdb transaction, exposing entry-oriented operations that run
+// atomically within an Update or View.
+type Tx struct {
+	tx *buntdb.Tx
+}
+
+// Update runs fn within a read-write transaction, committing its changes if
+// fn returns nil and rolling them back otherwise.
+func (s *Store) Update(fn func(tx *Tx) error) error {
+	return s.db.Update(func(btx *buntdb.Tx) error {
+		return fn(&Tx{tx: btx})
+	})
+}
+
+// View runs fn within a read-only transaction.
+func (s *Store) View(fn func(tx *Tx) error) error {
+	return s.db.View(func(btx *buntdb.Tx) error {
+		return fn(&Tx{tx: btx})
+	})
+}
+
+// Put inserts or updates e. e.ID == 0 is treated as a new entry and assigned
+// the next available ID.
+func (s *Store) Put(e *review.Entry) error {
+	return s.Update(func(tx *Tx) error {
+		return tx.Put(e)
+	})
+}
+
+// Put inserts or updates e within tx.
+func (tx *Tx) Put(e *review.Entry) error {
+	if e.ID == 0 {
+		id, err := tx.nextID()
+		if err != nil {
+			return err
+		}
+		e.ID = id
+	}
+
+	data, err := json.Marshal(record{Version: schemaVersion, Entry: e})
+	if err != nil {
+		return fmt.Errorf("store: marshal entry %d: %w", e.ID, err)
+	}
+
+	if _, _, err := tx.tx.Set(entryKey(e.ID), string(data), nil); err != nil {
+		return fmt.Errorf("store: put entry %d: %w", e.ID, err)
+	}
+	return nil
+}
+
+func (tx *Tx) nextID() (int, error) {
+	id := 1
+	val, err := tx.tx.Get(nextIDKey)
+	switch {
+	case err == nil:
+		if n, convErr := strconv.Atoi(val); convErr == nil {
+			id = n
+		}
+	case err == buntdb.ErrNotFound:
+		// first entry in the store
+	default:
+		return 0, fmt.Errorf("store: read next id: %w", err)
+	}
+
+	// Skip IDs already taken by entries that were Put with an explicit ID.
+	for {
+		_, err := tx.tx.Get(entryKey(id))
+		if err == buntdb.ErrNotFound {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("store: read entry %d: %w", id, err)
+		}
+		id++
+	}
+
+	if _, _, err := tx.tx.Set(nextIDKey, strconv.Itoa(id+1), nil); err != nil {
+		return 0, fmt.Errorf("store: advance next id: %w", err)
+	}
+	return id, nil
+}
+
+// Get returns the entry with the given ID, or ErrNotFound.
+func (s *Store) Get(id int) (*review.Entry, error) {
+	var entry *review.Entry
+	err := s.View(func(tx *Tx) error {
+		e, err := tx.Get(id)
+		entry = e
+		return err
+	})
+	return entry, err
+}
+
+// Get returns the entry with the given ID within tx, or ErrNotFound.
+func (tx *Tx) Get(id int) (*review.Entry, error) {
+	val, err := tx.tx.Get(entryKey(id))
+	if err != nil {
+		if err == buntdb.ErrNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("store: get entry %d: %w", id, err)
+	}
+	return decodeRecord(val)
+}
+
+// Delete removes the entry with the given ID, or returns ErrNotFound.
+func (s *Store) Delete(id int) error {
+	return s.Update(func(tx *Tx) error {
+		return tx.Delete(id)
+	})
+}
+
+// Delete removes the entry with the given ID within tx, or returns
+// ErrNotFound.
+func (tx *Tx) Delete(id int) error {
+	if _, err := tx.tx.Delete(entryKey(id)); err != nil {
+		if err == buntdb.ErrNotFound {
+			return ErrNotFound
+		}
+		return fmt.Errorf("store: delete entry %d: %w", id, err)
+	}
+	return nil
+}
+
+// ByCategory returns all entries in the given category.
+func (s *Store) ByCategory(cat string) ([]*review.Entry, error) {
+	var entries []*review.Entry
+	err := s.View(func(tx *Tx) error {
+		var iterErr error
+		tx.tx.Ascend("", func(key, val string) bool {
+			if !strings.HasPrefix(key, "entry:") {
+				return true
+			}
+			e, err := decodeRecord(val)
+			if err != nil {
+				iterErr = err
+				return false
+			}
+			if e.Category == cat {
+				entries = append(entries, e)
+			}
+			return true
+		})
+		return iterErr
+	})
+	return entries, err
+}
+
+// Due returns all entries whose ReviewDate is at or before now, read off the
+// review_date index so the lookup touches only due entries rather than
+// scanning the whole store.
+func (s *Store) Due(now time.Time) ([]*review.Entry, error) {
+	var entries []*review.Entry
+	err := s.View(func(tx *Tx) error {
+		var iterErr error
+		// AscendLessThan is strictly less-than, so nudge the pivot a
+		// nanosecond past now to include an entry whose ReviewDate exactly
+		// equals now.
+		tx.tx.AscendLessThan(dueIndex, reviewDatePivot(now.Add(time.Nanosecond)), func(key, val string) bool {
+			e, err := decodeRecord(val)
+			if err != nil {
+				iterErr = err
+				return false
+			}
+			entries = append(entries, e)
+			return true
+		})
+		return iterErr
+	})
+	return entries, err
+}
+
+// DueChan polls the due index every interval and returns a channel that
+// receives each entry the first time it's observed to be due, so a UI or
+// daemon can react without polling the whole store itself. The polling
+// goroutine and the returned channel are both stopped when the Store is
+// closed.
+func (s *Store) DueChan(interval time.Duration) <-chan *review.Entry {
+	ch := make(chan *review.Entry)
+	go func() {
+		defer close(ch)
+
+		seen := make(map[int]bool)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.done:
+				return
+			case <-ticker.C:
+				due, err := s.Due(time.Now())
+				if err != nil {
+					continue
+				}
+				for _, e := range due {
+					if seen[e.ID] {
+						continue
+					}
+					seen[e.ID] = true
+					select {
+					case ch <- e:
+					case <-s.done:
+						return
+					}
+				}
+			}
+		}
+	}()
+	return ch
+}
+
+func decodeRecord(val string) (*review.Entry, error) {
+	var rec record
+	if err := json.Unmarshal([]byte(val), &rec); err != nil {
+		return nil, fmt.Errorf("store: decode entry: %w", err)
+	}
+	return rec.Entry, nil
+}
+
+// lessReviewDate orders records by their entry's ReviewDate as a point in
+// time, so timestamps with different zone offsets or trimmed fractional
+// seconds compare correctly, which a string comparison would not.
+func lessReviewDate(a, b string) bool {
+	return recordReviewDate(a).Before(recordReviewDate(b))
+}
+
+func recordReviewDate(val string) time.Time {
+	var rec struct {
+		Entry struct{ ReviewDate time.Time } `json:"entry"`
+	}
+	_ = json.Unmarshal([]byte(val), &rec)
+	return rec.Entry.ReviewDate
+}
+
+// reviewDatePivot builds a pivot value for use with the review_date index.
+// lessReviewDate decodes both sides of the comparison as records, so the
+// pivot must be shaped like a stored record rather than a bare timestamp.
+func reviewDatePivot(t time.Time) string {
+	b, _ := json.Marshal(record{Entry: &review.Entry{ReviewDate: t}})
+	return string(b)
+}
@@ -0,0 +1,217 @@
+package store_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/eeay0/cortex/internal/review"
+	"github.com/eeay0/cortex/internal/review/store"
+)
+
+func openTestStore(t *testing.T) *store.Store {
+	t.Helper()
+	s, err := store.Open(filepath.Join(t.TempDir(), "cortex.db"))
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestStore_PutAssignsID(t *testing.T) {
+	s := openTestStore(t)
+
+	entry, err := review.NewEntry("test entry")
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+
+	if err := s.Put(entry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.ID == 0 {
+		t.Error("expected ID to be assigned, got 0")
+	}
+
+	got, err := s.Get(entry.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Title != entry.Title {
+		t.Errorf("expected title %q, got %q", entry.Title, got.Title)
+	}
+}
+
+func TestStore_GetMissing(t *testing.T) {
+	s := openTestStore(t)
+
+	if _, err := s.Get(42); err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestStore_Delete(t *testing.T) {
+	s := openTestStore(t)
+
+	entry, err := review.NewEntry("test entry")
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+	if err := s.Put(entry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := s.Delete(entry.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.Get(entry.ID); err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+	if err := s.Delete(entry.ID); err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound deleting again, got %v", err)
+	}
+}
+
+func TestStore_ByCategory(t *testing.T) {
+	s := openTestStore(t)
+
+	match, err := review.NewEntry("match", review.WithCategory("go"))
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+	other, err := review.NewEntry("other", review.WithCategory("rust"))
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+
+	if err := s.Put(match); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Put(other); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := s.ByCategory("go")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != match.ID {
+		t.Errorf("expected only %q entry, got %v", "go", got)
+	}
+}
+
+func TestStore_Due(t *testing.T) {
+	s := openTestStore(t)
+
+	overdue, err := review.NewEntry("overdue")
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+	overdue.ReviewDate = time.Now().Add(-time.Hour)
+
+	future, err := review.NewEntry("future")
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+	future.ReviewDate = time.Now().Add(day)
+
+	if err := s.Put(overdue); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Put(future); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	due, err := s.Due(time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(due) != 1 || due[0].ID != overdue.ID {
+		t.Errorf("expected only the overdue entry, got %v", due)
+	}
+}
+
+func TestStore_DueIncludesEntryExactlyAtNow(t *testing.T) {
+	s := openTestStore(t)
+
+	now := time.Now()
+	entry, err := review.NewEntry("right on time")
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+	entry.ReviewDate = now
+
+	if err := s.Put(entry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	due, err := s.Due(now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(due) != 1 || due[0].ID != entry.ID {
+		t.Errorf("expected the entry due exactly at now to be included, got %v", due)
+	}
+}
+
+const day = 24 * time.Hour
+
+func TestStore_Transaction(t *testing.T) {
+	s := openTestStore(t)
+
+	entry, err := review.NewEntry("test entry")
+	if err != nil {
+		t.Fatalf("failed to crate entry: %v", err)
+	}
+	if err := s.Put(entry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = s.Update(func(tx *store.Tx) error {
+		got, err := tx.Get(entry.ID)
+		if err != nil {
+			return err
+		}
+		if err := got.UpdateRecall(review.Good); err != nil {
+			return err
+		}
+		if err := got.UpdateInterval(); err != nil {
+			return err
+		}
+		return tx.Put(got)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := s.Get(entry.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Recall != review.Good {
+		t.Errorf("expected recall %v, got %v", review.Good, got.Recall)
+	}
+}
+
+func TestStore_DueChanStopsOnClose(t *testing.T) {
+	s, err := store.Open(filepath.Join(t.TempDir(), "cortex.db"))
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+
+	ch := s.DueChan(time.Millisecond)
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Error("expected channel to be closed with no pending entries")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("DueChan goroutine did not stop after Close")
+	}
+}